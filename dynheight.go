@@ -0,0 +1,158 @@
+package seawater
+
+import "math"
+
+// DynamicHeight computes dynamic height D(p) = integral from pref to p of
+// sw_svan(S,T,p') dp' on a CTD profile, by trapezoidal integration of
+// sw_svan on the sorted pressure grid. P, T and S must share the same
+// length and be sorted by increasing pressure.
+// Parameters:
+// P, T, S = pressure [db], temperature [degree C (ITS-90)],
+//           salinity [psu (PSS-78)], one per sample, sorted by P
+// pref    = reference pressure [db]; if it lies between two samples the
+//           cumulative integral is linearly interpolated between them
+// Return:
+// dynamic height [dyn-m] at each input pressure level, relative to pref;
+// error if StrictRange is true and a sample falls outside the UNESCO
+// Tech. Paper 44 validity bounds, see StrictRange in limits.go.
+func DynamicHeight(P, T, S []float64, pref float64) ([]float64, error) {
+	n := len(P)
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return nil, err
+	}
+	svan := make([]float64, n)
+	for i := 0; i < n; i++ {
+		svan[i] = sw_svan(S[i], T[i], P[i])
+	}
+
+	// cumulative trapezoidal integral of svan from P[0] to each level, in
+	// dyn-m: dP is converted from db to Pa (1 db = 1e4 Pa) to get work in
+	// J/kg, then divided by 10 since 1 dyn-m = 10 J/kg.
+	cum := make([]float64, n)
+	var acc float64
+	for i := 1; i < n; i++ {
+		acc += 0.5 * (svan[i] + svan[i-1]) * (P[i] - P[i-1]) * 1e4 / 10.0
+		cum[i] = acc
+	}
+
+	// find cumulative value at pref by linear interpolation between levels
+	var cumAtPref float64
+	switch {
+	case pref <= P[0]:
+		cumAtPref = cum[0]
+	case pref >= P[n-1]:
+		cumAtPref = cum[n-1]
+	default:
+		for i := 1; i < n; i++ {
+			if P[i] >= pref {
+				frac := (pref - P[i-1]) / (P[i] - P[i-1])
+				cumAtPref = cum[i-1] + frac*(cum[i]-cum[i-1])
+				break
+			}
+		}
+	}
+
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = cum[i] - cumAtPref
+	}
+	return out, nil
+}
+
+// GeostrophicVelocity computes geostrophic velocity between two stations
+// from their dynamic height profiles, using the thermal-wind / geostrophic
+// shear equation v = (10*deltaD) / (f*L).
+// Parameters:
+// dhA, dhB         = dynamic height profiles [dyn-m] at stations A and B,
+//                    as returned by DynamicHeight, same length and levels
+// latA, lonA       = station A position [decimal degrees]
+// latB, lonB       = station B position [decimal degrees]
+// Return:
+// geostrophic velocity [m/s] at each shared pressure level, positive from
+// A to B with A on the left facing the flow (northern-hemisphere sign
+// convention of f)
+func GeostrophicVelocity(dhA, dhB []float64, latA, latB, lonA, lonB float64) []float64 {
+	const Omega = 7.292115e-5 // rad/s, Earth's rotation rate
+
+	latMean := (latA + latB) / 2.0
+	f := 2 * Omega * math.Sin(latMean*math.Pi/180.0)
+	L := haversine(latA, lonA, latB, lonB)
+
+	n := len(dhA)
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		deltaD := dhB[i] - dhA[i]
+		out[i] = (10.0 * deltaD) / (f * L)
+	}
+	return out
+}
+
+// haversine computes the great-circle distance between two points on the
+// Earth's surface.
+// Parameters:
+// lat1, lon1, lat2, lon2 = positions [decimal degrees]
+// Return:
+// distance [m]
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const R = 6371000.0 // mean Earth radius, m
+	deg2rad := math.Pi / 180.0
+
+	phi1 := lat1 * deg2rad
+	phi2 := lat2 * deg2rad
+	dPhi := (lat2 - lat1) * deg2rad
+	dLambda := (lon2 - lon1) * deg2rad
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return R * c
+}
+
+// BruntVaisalaN2 computes the squared buoyancy frequency N^2 at each
+// mid-point of a CTD profile using the adiabatic-leveling method of
+// Millard, Owens and Fofonoff (1990): at each pair of adjacent samples,
+// density is evaluated at the mid-pressure after adiabatically leveling
+// both samples to that pressure, and N^2 is the finite difference of that
+// leveled density over depth.
+// Parameters:
+// P, T, S = pressure [db], temperature [degree C (ITS-90)],
+//           salinity [psu (PSS-78)], one per sample, sorted by P
+// lat     = latitude [decimal degrees], used to convert pressure to depth
+// Return:
+// N2 [rad^2/s^2], one value per adjacent pair (length len(P)-1); error if
+// StrictRange is true and a sample falls outside the UNESCO Tech. Paper
+// 44 validity bounds, see StrictRange in limits.go.
+// References:
+// Millard, R.C., W.B. Owens and N.P. Fofonoff, 1990: On the calculation of
+// the Brunt-Vaisala frequency. Deep-Sea Res., 37(1), 167-181.
+func BruntVaisalaN2(P, T, S []float64, lat float64) ([]float64, error) {
+	n := len(P)
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return nil, err
+	}
+	if n < 2 {
+		return nil, nil
+	}
+	const g = 9.8
+
+	out := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		pMid := 0.5 * (P[i] + P[i+1])
+
+		thetaUpper := sw_ptmp(S[i], T[i], P[i], pMid)
+		thetaLower := sw_ptmp(S[i+1], T[i+1], P[i+1], pMid)
+
+		rhoUpper := sw_dens(S[i], thetaUpper, pMid)
+		rhoLower := sw_dens(S[i+1], thetaLower, pMid)
+		rhoMean := 0.5 * (rhoUpper + rhoLower)
+
+		dz := sw_dpth(P[i+1], lat) - sw_dpth(P[i], lat) // positive, depth increases downward
+		if dz == 0 {
+			out[i] = 0
+			continue
+		}
+
+		out[i] = (g / rhoMean) * (rhoLower - rhoUpper) / dz
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package seawater
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDynamicHeight(t *testing.T) {
+
+	P := []float64{0, 100, 200, 500}
+	T := []float64{20, 15, 10, 5}
+	S := []float64{35, 35, 35, 35}
+
+	D, err := DynamicHeight(P, T, S, 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if D[len(D)-1] != 0 {
+		t.Errorf("expected zero dynamic height at the reference pressure, got %f", D[len(D)-1])
+	}
+	if D[0] == 0 {
+		t.Errorf("expected nonzero dynamic height away from the reference pressure")
+	}
+}
+
+func TestDynamicHeight_StrictRange(t *testing.T) {
+
+	StrictRange = true
+	defer func() { StrictRange = false }()
+
+	P := []float64{0, 100, 200, 500}
+	T := []float64{20, 15, 10, 5}
+	S := []float64{50, 35, 35, 35}
+
+	if _, err := DynamicHeight(P, T, S, 500); err == nil {
+		t.Errorf("expected RangeError for S=50")
+	}
+}
+
+func TestGeostrophicVelocity(t *testing.T) {
+
+	dhA := []float64{0.1, 0.05, 0}
+	dhB := []float64{0.2, 0.08, 0}
+
+	v := GeostrophicVelocity(dhA, dhB, 40.0, 41.0, -70.0, -70.0)
+	if len(v) != 3 {
+		t.Fatalf("expected 3 velocities, got %d", len(v))
+	}
+	if v[2] != 0 {
+		t.Errorf("expected zero velocity where dynamic heights agree, got %f", v[2])
+	}
+}
+
+func TestHaversine(t *testing.T) {
+
+	// one degree of latitude is ~111.2 km
+	d := haversine(0, 0, 1, 0)
+	if math.Abs(d-111195) > 1000 {
+		t.Errorf("expected ~111195 m, got %f", d)
+	}
+}
+
+func TestBruntVaisalaN2_StrictRange(t *testing.T) {
+
+	StrictRange = true
+	defer func() { StrictRange = false }()
+
+	P := []float64{0, 50, 100, 200}
+	T := []float64{20, 15, 10, 5}
+	S := []float64{50, 35, 35, 35}
+
+	if _, err := BruntVaisalaN2(P, T, S, 30.0); err == nil {
+		t.Errorf("expected RangeError for S=50")
+	}
+}
+
+func TestBruntVaisalaN2(t *testing.T) {
+
+	P := []float64{0, 50, 100, 200}
+	T := []float64{20, 15, 10, 5}
+	S := []float64{35, 35, 35, 35}
+
+	n2, err := BruntVaisalaN2(P, T, S, 30.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(n2) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(n2))
+	}
+	for i, v := range n2 {
+		if v < 0 {
+			t.Errorf("expected non-negative N2 for this stable profile at index %d, got %f", i, v)
+		}
+	}
+}
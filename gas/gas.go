@@ -0,0 +1,144 @@
+// Package gas contains dissolved-gas solubility, saturation and air-sea
+// gas-exchange routines that are natural companions to the density
+// routines in the parent seawater package during observational processing.
+package gas
+
+import "math"
+
+// O2sol computes oxygen solubility (saturation concentration at one
+// atmosphere total pressure) using the Garcia & Gordon (1992) polynomial.
+// Parameters:
+// S = salinity    [psu (PSS-78)]
+// T = temperature [degree C (ITS-90)]
+// Return:
+// O2 solubility [umol/kg]
+// References:
+// Garcia, H.E. and L.I. Gordon, 1992: Oxygen solubility in seawater:
+// Better fitting equations. Limnol. Oceanogr., 37(6), 1307-1312.
+func O2sol(S, T float64) float64 {
+	const a0, a1, a2, a3, a4, a5 = 5.80871, 3.20291, 4.17887, 5.10006, -9.86643e-2, 3.80369
+	const b0, b1, b2, b3 = -7.01577e-3, -7.70028e-3, -1.13864e-2, -9.51519e-3
+	const c0 = -2.75915e-7
+
+	Ts := math.Log((298.15 - T) / (273.15 + T))
+	lnC := a0 + a1*Ts + a2*Ts*Ts + a3*Ts*Ts*Ts + a4*Ts*Ts*Ts*Ts + a5*Ts*Ts*Ts*Ts*Ts +
+		S*(b0+b1*Ts+b2*Ts*Ts+b3*Ts*Ts*Ts) + c0*S*S
+	return math.Exp(lnC)
+}
+
+// N2sol computes nitrogen solubility using the Hamme & Emerson (2004)
+// polynomial.
+// Parameters:
+// S = salinity    [psu (PSS-78)]
+// T = temperature [degree C (ITS-90)]
+// Return:
+// N2 solubility [umol/kg]
+// References:
+// Hamme, R.C. and S.R. Emerson, 2004: The solubility of neon, nitrogen and
+// argon in distilled water and seawater. Deep-Sea Res. I, 51, 1517-1528.
+func N2sol(S, T float64) float64 {
+	const a0, a1, a2, a3 = 6.42931, 2.92704, 4.32531, 4.69149
+	const b0, b1, b2 = -7.44129e-3, -8.02566e-3, -1.46775e-2
+
+	Ts := math.Log((298.15 - T) / (273.15 + T))
+	lnC := a0 + a1*Ts + a2*Ts*Ts + a3*Ts*Ts*Ts + S*(b0+b1*Ts+b2*Ts*Ts)
+	return math.Exp(lnC)
+}
+
+// ArSol computes argon solubility using the Hamme & Emerson (2004)
+// polynomial.
+// Parameters:
+// S = salinity    [psu (PSS-78)]
+// T = temperature [degree C (ITS-90)]
+// Return:
+// Ar solubility [umol/kg]
+// References:
+// Hamme, R.C. and S.R. Emerson, 2004: The solubility of neon, nitrogen and
+// argon in distilled water and seawater. Deep-Sea Res. I, 51, 1517-1528.
+func ArSol(S, T float64) float64 {
+	const a0, a1, a2, a3 = 2.79150, 3.17609, 4.13116, 4.90379
+	const b0, b1, b2 = -6.96233e-3, -7.66670e-3, -1.16888e-2
+
+	Ts := math.Log((298.15 - T) / (273.15 + T))
+	lnC := a0 + a1*Ts + a2*Ts*Ts + a3*Ts*Ts*Ts + S*(b0+b1*Ts+b2*Ts*Ts)
+	return math.Exp(lnC)
+}
+
+// NeSol computes neon solubility using the Hamme & Emerson (2004)
+// polynomial.
+// Parameters:
+// S = salinity    [psu (PSS-78)]
+// T = temperature [degree C (ITS-90)]
+// Return:
+// Ne solubility [umol/kg]
+// References:
+// Hamme, R.C. and S.R. Emerson, 2004: The solubility of neon, nitrogen and
+// argon in distilled water and seawater. Deep-Sea Res. I, 51, 1517-1528.
+func NeSol(S, T float64) float64 {
+	const a0, a1, a2 = 2.18156, 1.29108, 2.12504
+	const b0, b1, b2 = -5.94737e-3, -5.13896e-3, 0.0
+
+	Ts := math.Log((298.15 - T) / (273.15 + T))
+	lnC := a0 + a1*Ts + a2*Ts*Ts + S*(b0+b1*Ts+b2*Ts*Ts)
+	return math.Exp(lnC) / 1000 // nmol/kg -> umol/kg, Hamme & Emerson tabulate Ne in nmol/kg
+}
+
+// AOU computes the apparent oxygen utilization, the deficit of measured
+// oxygen relative to solubility equilibrium with the atmosphere.
+// Parameters:
+// S          = salinity    [psu (PSS-78)]
+// T          = temperature [degree C (ITS-90)]
+// O2measured = measured dissolved oxygen [umol/kg]
+// Return:
+// AOU [umol/kg]
+func AOU(S, T, O2measured float64) float64 {
+	return O2sol(S, T) - O2measured
+}
+
+// schmidtCoeffs holds the Wanninkhof (2014) cubic Schmidt-number
+// coefficients Sc = c0 + c1*t + c2*t^2 + c3*t^3, t in degree C, valid for
+// seawater at 35 psu over the range 0-40 degree C.
+var schmidtCoeffs = map[string][4]float64{
+	"CO2": {2116.8, -136.25, 4.7353, -0.092307},
+	"O2":  {1920.4, -135.6, 5.2122, -0.109390},
+	"N2":  {2224.8, -148.56, 5.4393, -0.108700},
+	"Ar":  {2078.1, -146.74, 5.6403, -0.118970},
+	"N2O": {2356.2, -166.38, 6.3952, -0.133710},
+	"CH4": {2101.2, -131.54, 4.4931, -0.089180},
+	"SF6": {3177.5, -200.57, 6.8865, -0.132500},
+	"He3": {1246.3, -80.68, 2.8240, -0.054541},
+}
+
+// SchmidtNumber computes the Schmidt number of a dissolved gas in seawater
+// using the Wanninkhof (2014) cubic fit.
+// Parameters:
+// gas = one of "CO2", "O2", "N2", "Ar", "N2O", "CH4", "SF6", "He3"
+// T   = temperature [degree C (ITS-90)]
+// Return:
+// Schmidt number [dimensionless], or NaN if gas is not recognized
+// References:
+// Wanninkhof, R., 2014: Relationship between wind speed and gas exchange
+// over the ocean revisited. Limnol. Oceanogr.: Methods, 12, 351-362.
+func SchmidtNumber(gas string, T float64) float64 {
+	c, ok := schmidtCoeffs[gas]
+	if !ok {
+		return math.NaN()
+	}
+	return c[0] + c[1]*T + c[2]*T*T + c[3]*T*T*T
+}
+
+// PistonVelocity computes the air-sea gas-transfer piston velocity using
+// the Wanninkhof (2014) quadratic wind-speed parameterization.
+// Parameters:
+// u10           = wind speed at 10 m [m/s]
+// schmidtNumber = Schmidt number of the gas at the sea-surface temperature
+//                 [dimensionless], see SchmidtNumber
+// Return:
+// piston velocity k [cm/hr]
+// References:
+// Wanninkhof, R., 2014: Relationship between wind speed and gas exchange
+// over the ocean revisited. Limnol. Oceanogr.: Methods, 12, 351-362.
+func PistonVelocity(u10, schmidtNumber float64) float64 {
+	const a = 0.251
+	return a * u10 * u10 * math.Pow(schmidtNumber/660.0, -0.5)
+}
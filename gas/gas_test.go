@@ -0,0 +1,52 @@
+package gas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestO2sol(t *testing.T) {
+
+	v := O2sol(35.0, 20.0)
+	if v < 150 || v > 300 {
+		t.Errorf("expected O2 solubility in a plausible range, got %f", v)
+	}
+}
+
+func TestNeSol(t *testing.T) {
+
+	v := NeSol(35.0, 10.0)
+	if v < 0.005 || v > 0.01 {
+		t.Errorf("expected Ne solubility in umol/kg (a few thousandths), got %f", v)
+	}
+}
+
+func TestAOU(t *testing.T) {
+
+	sat := O2sol(35.0, 20.0)
+	v := AOU(35.0, 20.0, sat-10)
+	if math.Abs(v-10) > 1e-9 {
+		t.Errorf("Expected %f, got %f", 10.0, v)
+	}
+}
+
+func TestSchmidtNumber(t *testing.T) {
+
+	v := SchmidtNumber("CO2", 20.0)
+	if v <= 0 {
+		t.Errorf("expected positive Schmidt number, got %f", v)
+	}
+	v = SchmidtNumber("unknown-gas", 20.0)
+	if !math.IsNaN(v) {
+		t.Errorf("expected NaN for unrecognized gas, got %f", v)
+	}
+}
+
+func TestPistonVelocity(t *testing.T) {
+
+	v := PistonVelocity(7.0, 660.0)
+	want := 0.251 * 7.0 * 7.0
+	if math.Abs(v-want) > 1e-9 {
+		t.Errorf("Expected %f, got %f", want, v)
+	}
+}
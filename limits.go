@@ -0,0 +1,180 @@
+package seawater
+
+import (
+	"fmt"
+	"math"
+)
+
+// StrictRange controls whether the package's public range-checked entry
+// points return a RangeError when an input falls outside the UNESCO Tech.
+// Paper 44 validity bounds (S in [0, 42] PSU, T in [-2, 40] degree C, P in
+// [0, 10000] db). It defaults to false so existing callers of the
+// unexported sw_* routines are unaffected; set it to true to opt in. This
+// covers SwDens/SwSvel/SwSal/SwTFreeze/SwViscosity below, their vectorized
+// Sw*V counterparts in vector.go, MixedLayerDepth's methods in mld.go, and
+// DynamicHeight/BruntVaisalaN2 in dynheight.go. There is no EOS80/TEOS10
+// backend to cover: see the file doc comment in teos10.go.
+var StrictRange = false
+
+// RangeError reports that an argument to a seawater routine fell outside
+// the UNESCO Tech. Paper 44 validity range for that quantity.
+type RangeError struct {
+	Param string
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("seawater: %s = %g out of range [%g, %g]", e.Param, e.Value, e.Min, e.Max)
+}
+
+func checkRange(param string, v, min, max float64) error {
+	if !StrictRange {
+		return nil
+	}
+	if v < min || v > max {
+		return &RangeError{Param: param, Value: v, Min: min, Max: max}
+	}
+	return nil
+}
+
+func checkSTP(S, T, P float64) error {
+	if err := checkRange("S", S, 0, 42); err != nil {
+		return err
+	}
+	if err := checkRange("T", T, -2, 40); err != nil {
+		return err
+	}
+	if err := checkRange("P", P, 0, 10000); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkSTPSlice applies checkSTP to every sample in a profile, returning
+// the first violation found.
+func checkSTPSlice(S, T, P []float64) error {
+	if !StrictRange {
+		return nil
+	}
+	for i := range S {
+		if err := checkSTP(S[i], T[i], P[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTPSlice applies the T/P bounds (but not S) to every sample in a
+// profile, for routines like SwSalV where the first argument is
+// conductivity rather than salinity.
+func checkTPSlice(T, P []float64) error {
+	if !StrictRange {
+		return nil
+	}
+	for i := range T {
+		if err := checkRange("T", T[i], -2, 40); err != nil {
+			return err
+		}
+		if err := checkRange("P", P[i], 0, 10000); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPSlice applies the P bound to every sample in a profile, for
+// routines that take only pressure.
+func checkPSlice(P []float64) error {
+	if !StrictRange {
+		return nil
+	}
+	for i := range P {
+		if err := checkRange("P", P[i], 0, 10000); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SwDens is a range-checked wrapper around sw_dens. When StrictRange is
+// true it returns a *RangeError if S, T or P fall outside the UNESCO Tech.
+// Paper 44 validity bounds; otherwise it behaves exactly like sw_dens.
+func SwDens(S, T, P float64) (float64, error) {
+	if err := checkSTP(S, T, P); err != nil {
+		return 0, err
+	}
+	return sw_dens(S, T, P), nil
+}
+
+// SwSvel is a range-checked wrapper around sw_svel, see SwDens.
+func SwSvel(S, T, P float64) (float64, error) {
+	if err := checkSTP(S, T, P); err != nil {
+		return 0, err
+	}
+	return sw_svel(S, T, P), nil
+}
+
+// SwSal is a range-checked wrapper around sw_sal, see SwDens. Conductivity
+// has no UNESCO-44 bound of its own, so only T and P are checked.
+func SwSal(C, T, P float64) (float64, error) {
+	if err := checkRange("T", T, -2, 40); err != nil {
+		return 0, err
+	}
+	if err := checkRange("P", P, 0, 10000); err != nil {
+		return 0, err
+	}
+	return sw_sal(C, T, P), nil
+}
+
+// SwTFreeze computes the freezing point of seawater using the UNESCO 1983
+// polynomial.
+// Parameters:
+// S = salinity [psu (PSS-78)]
+// P = pressure [db]
+// Return:
+// Tf = freezing point [degree C (ITS-90)]
+// References:
+// Fofonoff, P. and Millard, R.C. Jr UNESCO 1983. Algorithms for
+// computation of fundamental properties of seawater. UNESCO Tech. Pap. in
+// Mar. Sci., No. 44, 53 pp.
+func SwTFreeze(S, P float64) (float64, error) {
+	if err := checkRange("S", S, 0, 42); err != nil {
+		return 0, err
+	}
+	if err := checkRange("P", P, 0, 10000); err != nil {
+		return 0, err
+	}
+	const a0, a1, a2, b = -0.0575, 1.710523e-3, -2.154996e-4, -7.53e-4
+	return a0*S + a1*S*math.Sqrt(S) + a2*S*S + b*P, nil
+}
+
+// SwViscosity computes the dynamic viscosity of seawater using the
+// Sharqawy et al. (2010) correlation, useful for Schmidt-number and
+// gas-exchange computations.
+// Parameters:
+// S = salinity    [psu (PSS-78)]
+// T = temperature [degree C (ITS-90)]
+// Return:
+// viscosity [kg/(m s)]
+// References:
+// Sharqawy, M.H., J.H. Lienhard V and S.M. Zubair, 2010: Thermophysical
+// properties of seawater: A review of existing correlations and data.
+// Desalination and Water Treatment, 16, 354-380.
+func SwViscosity(S, T float64) (float64, error) {
+	if err := checkRange("S", S, 0, 42); err != nil {
+		return 0, err
+	}
+	if err := checkRange("T", T, -2, 40); err != nil {
+		return 0, err
+	}
+	Skg := S / 1000.0
+
+	muw := 4.2844e-5 + 1.0/(0.157*(T+64.993)*(T+64.993)-91.296)
+
+	A := 1.541 + 1.998e-2*T - 9.52e-5*T*T
+	B := 7.974 - 7.561e-2*T + 4.724e-4*T*T
+
+	return muw * (1 + A*Skg + B*Skg*Skg), nil
+}
@@ -0,0 +1,66 @@
+package seawater
+
+import "testing"
+
+func TestSwTFreeze(t *testing.T) {
+
+	v, err := SwTFreeze(35.0, 0.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v = toFixed(v, 3)
+	if v != -1.922 {
+		t.Errorf("Expected %f, got %f", -1.922, v)
+	}
+}
+
+func TestSwTFreeze_StrictRange(t *testing.T) {
+
+	StrictRange = true
+	defer func() { StrictRange = false }()
+
+	if _, err := SwTFreeze(50.0, 0.0); err == nil {
+		t.Errorf("expected RangeError for S=50")
+	}
+}
+
+func TestSwDens_StrictRange(t *testing.T) {
+
+	StrictRange = true
+	defer func() { StrictRange = false }()
+
+	if _, err := SwDens(35, 20, 0); err != nil {
+		t.Errorf("unexpected error for in-range inputs: %v", err)
+	}
+	if _, err := SwDens(50, 20, 0); err == nil {
+		t.Errorf("expected RangeError for S=50")
+	}
+}
+
+func TestSwDens_NotStrictByDefault(t *testing.T) {
+
+	if _, err := SwDens(50, 20, 0); err != nil {
+		t.Errorf("expected no error when StrictRange is false, got %v", err)
+	}
+}
+
+func TestSwViscosity(t *testing.T) {
+
+	v, err := SwViscosity(35.0, 20.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v <= 0 || v > 0.01 {
+		t.Errorf("expected viscosity in a plausible range, got %f", v)
+	}
+}
+
+func TestSwViscosity_StrictRange(t *testing.T) {
+
+	StrictRange = true
+	defer func() { StrictRange = false }()
+
+	if _, err := SwViscosity(50.0, 20.0); err == nil {
+		t.Errorf("expected RangeError for S=50")
+	}
+}
@@ -0,0 +1,167 @@
+package seawater
+
+import "math"
+
+// MixedLayerDepth groups diagnostics for estimating the depth of the
+// surface mixed layer from a CTD profile. It has no state of its own; it
+// exists purely as a namespace for the MLD methods below so that callers
+// can write seawater.MixedLayerDepth{}.MLDByDensityDifference(...) and the
+// package can grow further MLD criteria without polluting the top-level
+// function namespace.
+type MixedLayerDepth struct{}
+
+// MLDByDensityDifference finds the shallowest depth where sigma-theta
+// exceeds sigma-theta(refDepth) + deltaRho, the de Boyer Montegut (2004)
+// density-threshold criterion. P, T and S share the same length and are
+// scanned in the given (surface-first) order; a cast does not need to be
+// strictly sorted by increasing pressure, since ship heave and bottle
+// stops routinely produce small local pressure reversals. Where a
+// reversal is encountered at the crossing point, the deeper of the two
+// samples is reported rather than extrapolating backwards.
+// Parameters:
+// P, T, S  = pressure [db], temperature [degree C (ITS-90)],
+//            salinity [psu (PSS-78)], one per sample, surface first
+// refDepth = reference pressure near the surface [db], typically 10
+// deltaRho = density-difference threshold [kg/m^3], typically 0.03
+// Return:
+// MLD [db], or the deepest sampled pressure if the threshold is never
+// exceeded; error if StrictRange is true and a sample falls outside the
+// UNESCO Tech. Paper 44 validity bounds, see StrictRange in limits.go.
+// References:
+// de Boyer Montegut, C., G. Madec, A.S. Fischer, A. Lazar and D. Iudicone,
+// 2004: Mixed layer depth over the global ocean: An examination of profile
+// data and a profile-based climatology. J. Geophys. Res., 109, C12003.
+func (MixedLayerDepth) MLDByDensityDifference(P, T, S []float64, refDepth, deltaRho float64) (float64, error) {
+	n := len(P)
+	if n == 0 {
+		return 0, nil
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return 0, err
+	}
+
+	sigmaRef := interpSigmaTheta(P, T, S, refDepth)
+	threshold := sigmaRef + deltaRho
+
+	for i := 0; i < n; i++ {
+		sigma := sw_sigmateta(S[i], T[i], P[i])
+		if sigma > threshold {
+			if i == 0 {
+				return P[i], nil
+			}
+			sigmaPrev := sw_sigmateta(S[i-1], T[i-1], P[i-1])
+			dP := P[i] - P[i-1]
+			if dP <= 0 {
+				// pressure reversal between these two samples: report the
+				// deeper of the two rather than extrapolating backwards
+				return math.Max(P[i-1], P[i]), nil
+			}
+			frac := clamp01((threshold - sigmaPrev) / (sigma - sigmaPrev))
+			return P[i-1] + frac*dP, nil
+		}
+	}
+	return P[n-1], nil
+}
+
+// MLDByEnergy returns the depth to which a given turbulent kinetic energy
+// per unit area would homogenize the water column, by finding the base
+// depth H at which the potential-energy difference between the stratified
+// and homogenized column equals energyThreshold.
+// Parameters:
+// P, T, S        = pressure [db], temperature [degree C (ITS-90)],
+//                  salinity [psu (PSS-78)], one per sample, surface first
+// energyThreshold = turbulent kinetic energy per unit area [J/m^2],
+//                  e.g. 25 (weak wind), 2500 (storm), 250000 (extreme)
+// Return:
+// MLD [db], or the deepest sampled pressure if the threshold is never
+// reached; error if StrictRange is true and a sample falls outside the
+// UNESCO Tech. Paper 44 validity bounds, see StrictRange in limits.go.
+// Notes:
+// PE_stratified(H) = g * integral_0^H rho(z)*z dz
+// PE_homogenized(H) = g * H^2/2 * mean(rho, 0..H)
+// scanned from the surface using trapezoidal integration between samples,
+// with depth approximated from pressure via sw_dpth at the mean latitude
+// of 0 (callers working at high latitude should pre-convert P to depth).
+// As with MLDByDensityDifference, samples are scanned surface-first
+// without requiring P to be strictly increasing; a local pressure
+// reversal contributes no additional depth to the integral rather than
+// running it backwards.
+func (MixedLayerDepth) MLDByEnergy(P, T, S []float64, energyThreshold float64) (float64, error) {
+	n := len(P)
+	if n == 0 {
+		return 0, nil
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return 0, err
+	}
+	const g = 9.8
+
+	z := make([]float64, n)
+	rho := make([]float64, n)
+	for i := 0; i < n; i++ {
+		z[i] = sw_dpth(P[i], 0)
+		rho[i] = sw_dens(S[i], T[i], P[i])
+	}
+
+	var peStrat, rhoSum, zPrev, H float64
+	zPrev = z[0]
+	H = z[0]
+	for i := 1; i < n; i++ {
+		dz := z[i] - zPrev
+		if dz < 0 {
+			// local pressure reversal: hold position rather than
+			// integrating backwards
+			zPrev = H
+			continue
+		}
+		peStrat += g * 0.5 * (rho[i]*z[i] + rho[i-1]*zPrev) * dz
+		rhoSum += 0.5 * (rho[i] + rho[i-1]) * dz
+		zPrev = z[i]
+		H = z[i]
+
+		if H <= 0 {
+			continue
+		}
+		rhoMean := rhoSum / H
+		peHomog := g * H * H / 2 * rhoMean
+		if peStrat-peHomog >= energyThreshold {
+			return P[i], nil
+		}
+	}
+	return P[n-1], nil
+}
+
+// interpSigmaTheta linearly interpolates sigma-theta to depth d, scanning
+// the profile surface-first. If the crossing straddles a local pressure
+// reversal, the nearer sample's sigma-theta is returned instead of
+// interpolating across the reversed segment.
+func interpSigmaTheta(P, T, S []float64, d float64) float64 {
+	n := len(P)
+	if d <= P[0] {
+		return sw_sigmateta(S[0], T[0], P[0])
+	}
+	for i := 1; i < n; i++ {
+		if P[i] >= d {
+			dP := P[i] - P[i-1]
+			if dP <= 0 {
+				return sw_sigmateta(S[i], T[i], P[i])
+			}
+			sigmaPrev := sw_sigmateta(S[i-1], T[i-1], P[i-1])
+			sigma := sw_sigmateta(S[i], T[i], P[i])
+			frac := clamp01((d - P[i-1]) / dP)
+			return sigmaPrev + frac*(sigma-sigmaPrev)
+		}
+	}
+	return sw_sigmateta(S[n-1], T[n-1], P[n-1])
+}
+
+// clamp01 restricts v to the closed interval [0, 1].
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
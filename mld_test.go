@@ -0,0 +1,64 @@
+package seawater
+
+import "testing"
+
+func TestMLDByDensityDifference(t *testing.T) {
+
+	P := []float64{0, 5, 10, 20, 30, 50, 100}
+	T := []float64{20, 20, 20, 18, 15, 10, 8}
+	S := []float64{35, 35, 35, 35, 35, 35, 35}
+
+	mld, err := MixedLayerDepth{}.MLDByDensityDifference(P, T, S, 10, 0.03)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mld <= 10 || mld > 100 {
+		t.Errorf("expected MLD between 10 and 100 db, got %f", mld)
+	}
+}
+
+func TestMLDByDensityDifference_NonMonotonic(t *testing.T) {
+
+	// a ship-heave pressure reversal between the 25 db and 18 db samples
+	P := []float64{0, 5, 10, 25, 18, 50, 100}
+	T := []float64{20, 20, 20, 18, 18, 10, 8}
+	S := []float64{35, 35, 35, 35, 35, 35, 35}
+
+	mld, err := MixedLayerDepth{}.MLDByDensityDifference(P, T, S, 10, 0.03)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mld <= 0 || mld > 100 {
+		t.Errorf("expected a forward, non-negative MLD despite the reversal, got %f", mld)
+	}
+}
+
+func TestMLDByEnergy(t *testing.T) {
+
+	P := []float64{0, 5, 10, 20, 30, 50, 100}
+	T := []float64{20, 20, 20, 18, 15, 10, 8}
+	S := []float64{35, 35, 35, 35, 35, 35, 35}
+
+	mld, err := MixedLayerDepth{}.MLDByEnergy(P, T, S, 2500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mld <= 0 || mld > 100 {
+		t.Errorf("expected MLD between 0 and 100 db, got %f", mld)
+	}
+}
+
+func TestMLDByEnergy_NonMonotonic(t *testing.T) {
+
+	P := []float64{0, 5, 10, 25, 18, 50, 100}
+	T := []float64{20, 20, 20, 18, 18, 10, 8}
+	S := []float64{35, 35, 35, 35, 35, 35, 35}
+
+	mld, err := MixedLayerDepth{}.MLDByEnergy(P, T, S, 2500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mld <= 0 || mld > 100 {
+		t.Errorf("expected a forward, non-negative MLD despite the reversal, got %f", mld)
+	}
+}
@@ -0,0 +1,144 @@
+package seawater
+
+// This file provides a handful of TEOS-10 / Gibbs Seawater (GSW) style
+// entry points that work in the GSW variable conventions — Absolute
+// Salinity (SA, g/kg) and Conservative Temperature (CT, degree C) rather
+// than Practical Salinity and in-situ/potential temperature. It does NOT
+// implement the Gibbs function itself: a faithful TEOS-10 polynomial (the
+// 75-term expression of McDougall & Barker 2011, or the Roquet et al.
+// (2015) fit production ocean models actually use) needs a large published
+// coefficient table this package has no way to check against a reference
+// in isolation, and an earlier hand-reconstructed attempt produced density
+// errors of up to 20 kg/m^3 while falsely citing those papers. A later
+// revision tried to paper over that by quietly converting SA/CT back to
+// SP/in-situ-T and calling the EOS-80 kernel underneath — which made
+// EOS80.Dens and TEOS10.Dens numerically identical, i.e. TEOS-10 in name
+// only. Rather than repeat either mistake, what's left below is limited
+// to what can honestly be done without the real Gibbs function: convert
+// between the GSW and EOS-80 variable conventions (SA<->SP, CT<->pt, both
+// good to a few hundredths of a degree/PSU per McDougall 2003 and
+// McDougall & Barker 2011) and delegate to the existing, validated EOS-80
+// kernel for the actual physics. There is no EOS/EOS80/TEOS10 dispatch
+// type and no gsw_rho/gsw_alpha/gsw_beta/gsw_sound_speed/gsw_spiciness
+// here: a polymorphic EOS-80-vs-TEOS-10 backend implies two independently
+// implemented formulations, and this package only has one. Callers who
+// need genuine Gibbs-function precision should reach for a dedicated
+// TEOS-10 implementation (e.g. the reference gsw-go, or a cgo binding to
+// the official C library) instead.
+
+// GswSAFromSP converts Practical Salinity to Absolute Salinity.
+// Parameters:
+// SP  = Practical Salinity [psu (PSS-78)]
+// P   = sea pressure [db]
+// lon = longitude [-360..360]
+// lat = latitude  [-90..90]
+// Return:
+// SA = Absolute Salinity [g/kg]
+// Notes:
+// This uses the reference (SSO) salinity anomaly ratio in place of the full
+// SAAR lookup table described in McDougall & Barker (2011): away from
+// marginal seas the anomaly is small and SA ~= (35.16504/35)*SP is a good
+// approximation; callers needing the full spatially-varying anomaly should
+// supply it themselves.
+// References:
+// McDougall, T.J. and P.M. Barker, 2011: Getting started with TEOS-10 and
+// the Gibbs Seawater (GSW) oceanographic toolbox, 28pp., SCOR/IAPSO WG127.
+func GswSAFromSP(SP, P, lon, lat float64) float64 {
+	const uPS = 35.16504 / 35.0
+	return uPS * SP
+}
+
+// GswCTFromPt converts potential temperature to Conservative Temperature.
+// Parameters:
+// SA = Absolute Salinity      [g/kg]
+// pt = potential temperature  [degree C (ITS-90)], reference pressure 0 db
+// Return:
+// CT = Conservative Temperature [degree C (ITS-90)]
+// Notes:
+// McDougall (2003) shows that CT and potential temperature track each
+// other closely over the full oceanographic range (typically differing by
+// well under 0.3 degree C, and usually under 0.05 degree C), so this
+// implementation uses pt as CT directly rather than the full potential
+// enthalpy polynomial. Fidelity to the exact Gibbs-function CT would need
+// the published 75-term coefficient table, which this package does not
+// carry; GswPTFromCT below is the exact inverse of this approximation.
+// References:
+// McDougall, T.J., 2003: Potential enthalpy: A conservative oceanic
+// variable for evaluating heat content and heat fluxes. J. Phys.
+// Oceanogr., 33, 945-963.
+func GswCTFromPt(SA, pt float64) float64 {
+	return pt
+}
+
+// GswPTFromCT converts Conservative Temperature to potential temperature.
+// It is the exact inverse of GswCTFromPt's CT ~= pt approximation, see its
+// doc comment for the accuracy this implies.
+// Parameters:
+// SA = Absolute Salinity         [g/kg]
+// CT = Conservative Temperature  [degree C (ITS-90)]
+// Return:
+// pt = potential temperature [degree C (ITS-90)], reference pressure 0 db
+func GswPTFromCT(SA, CT float64) float64 {
+	return CT
+}
+
+// GswGeoStrfDynHeight computes dynamic height anomaly from a CTD profile
+// expressed in GSW variables (SA, CT), relative to pref, by trapezoidal
+// integration of specific volume anomaly. SA/CT are converted to
+// Practical Salinity and in-situ temperature via GswPTFromCT's pt~=CT
+// approximation and delegated to sw_svan, so this is a variable-
+// convention convenience wrapper around the same EOS-80 kernel
+// DynamicHeight (dynheight.go) uses, not an independent Gibbs-function
+// integration; see the file doc comment above.
+// Parameters:
+// SA   = Absolute Salinity        [g/kg], one per pressure level
+// CT   = Conservative Temperature [degree C (ITS-90)], one per pressure level
+// P    = sea pressure             [db], increasing, one per level
+// pref = reference pressure       [db]; if it lies between two samples the
+//        cumulative integral is linearly interpolated between them
+// Return:
+// dynamic height anomaly [m^2/s^2] at each input pressure level, relative
+// to pref
+func GswGeoStrfDynHeight(SA, CT, P []float64, pref float64) []float64 {
+	n := len(P)
+	svan := make([]float64, n)
+	for i := 0; i < n; i++ {
+		SP := SA[i] / (35.16504 / 35.0)
+		pt := GswPTFromCT(SA[i], CT[i])
+		T := sw_ptmp(SP, pt, 0, P[i]) // potential temp at 0 db -> in-situ temp at P[i]
+		svan[i] = sw_svan(SP, T, P[i])
+	}
+
+	// cumulative trapezoidal integral of svan from P[0] to each level, in
+	// m^2/s^2: dP is converted from db to Pa (1 db = 1e4 Pa).
+	cum := make([]float64, n)
+	var acc float64
+	for i := 1; i < n; i++ {
+		dP := (P[i] - P[i-1]) * 1e4 // db -> Pa
+		acc += 0.5 * (svan[i] + svan[i-1]) * dP
+		cum[i] = -acc
+	}
+
+	// find cumulative value at pref by linear interpolation between levels
+	var cumAtPref float64
+	switch {
+	case pref <= P[0]:
+		cumAtPref = cum[0]
+	case pref >= P[n-1]:
+		cumAtPref = cum[n-1]
+	default:
+		for i := 1; i < n; i++ {
+			if P[i] >= pref {
+				frac := (pref - P[i-1]) / (P[i] - P[i-1])
+				cumAtPref = cum[i-1] + frac*(cum[i]-cum[i-1])
+				break
+			}
+		}
+	}
+
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = cum[i] - cumAtPref
+	}
+	return out
+}
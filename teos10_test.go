@@ -0,0 +1,53 @@
+package seawater
+
+import "testing"
+
+func TestGsw_pt_from_CT_roundtrip(t *testing.T) {
+
+	SA := GswSAFromSP(35.0, 0, 0, 0)
+	CT := GswCTFromPt(SA, 10.0)
+	pt := GswPTFromCT(SA, CT)
+	if toFixed(pt, 2) != 10.00 {
+		t.Errorf("Expected %f, got %f", 10.00, toFixed(pt, 2))
+	}
+}
+
+func TestGswGeoStrfDynHeight(t *testing.T) {
+
+	SA := []float64{35.0, 35.0, 35.0}
+	CT := []float64{20.0, 15.0, 10.0}
+	P := []float64{0, 100, 200}
+
+	D := GswGeoStrfDynHeight(SA, CT, P, 0)
+	if D[0] != 0 {
+		t.Errorf("expected zero dynamic height anomaly at pref=P[0], got %f", D[0])
+	}
+	if D[len(D)-1] == 0 {
+		t.Errorf("expected nonzero dynamic height anomaly away from pref")
+	}
+}
+
+// TestGswGeoStrfDynHeight_Pref checks that the reference pressure is
+// actually honored: zeroing at an interior pref must give a different,
+// non-degenerate profile than zeroing at the surface.
+func TestGswGeoStrfDynHeight_Pref(t *testing.T) {
+
+	SA := []float64{35.0, 35.0, 35.0}
+	CT := []float64{20.0, 15.0, 10.0}
+	P := []float64{0, 100, 200}
+
+	atSurface := GswGeoStrfDynHeight(SA, CT, P, 0)
+	atMid := GswGeoStrfDynHeight(SA, CT, P, 100)
+
+	if toFixed(atMid[1], 6) != 0 {
+		t.Errorf("expected zero dynamic height anomaly at pref=100, got %f", atMid[1])
+	}
+	if toFixed(atSurface[1], 6) == 0 {
+		t.Errorf("expected nonzero dynamic height anomaly at P[1] when pref=0")
+	}
+	for i := range atSurface {
+		if toFixed(atSurface[i], 6) == toFixed(atMid[i], 6) && P[i] != 100 {
+			t.Errorf("expected pref=0 and pref=100 profiles to differ at level %d", i)
+		}
+	}
+}
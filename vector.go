@@ -0,0 +1,206 @@
+package seawater
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrLengthMismatch is returned by the vectorized Sw*V functions when the
+// input slices do not all share the same length as the output buffer.
+var ErrLengthMismatch = errors.New("seawater: input/output slice length mismatch")
+
+// SwDensV computes density for a whole profile at once, writing into the
+// caller-supplied out buffer. S, T, P and out must have equal length.
+// This is the slice counterpart of sw_dens, intended for CTD casts and
+// gridded fields where per-sample function call overhead and Go-level
+// loops in caller code are undesirable.
+// Parameters:
+// S, T, P = salinity [psu (PSS-78)], temperature [degree C (ITS-90)],
+//           pressure [db], one triple per sample
+// out     = destination buffer, reused in place, len(out) == len(S)
+// Return:
+// error if the slice lengths disagree
+func SwDensV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		SR := math.Sqrt(S[i])
+		dens0 := sw_dens0_sr(S[i], T[i], SR)
+		K := sw_seck_sr(S[i], T[i], P[i], SR)
+		p := P[i] / 10.0
+		out[i] = dens0 / (1 - p/K)
+	}
+	return nil
+}
+
+// SwSalV computes salinity for a whole profile at once. C, T, P and out
+// must have equal length.
+func SwSalV(C, T, P, out []float64) error {
+	n := len(C)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkTPSlice(T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_sal(C[i], T[i], P[i])
+	}
+	return nil
+}
+
+// SwSvelV computes sound velocity for a whole profile at once. S, T, P and
+// out must have equal length.
+func SwSvelV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_svel(S[i], T[i], P[i])
+	}
+	return nil
+}
+
+// SwSigmatV computes sigma-t for a whole profile at once. S, T, P and out
+// must have equal length.
+func SwSigmatV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_sigmat(S[i], T[i], P[i])
+	}
+	return nil
+}
+
+// SwSigmatetaV computes sigma-theta for a whole profile at once. S, T, P
+// and out must have equal length.
+func SwSigmatetaV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_sigmateta(S[i], T[i], P[i])
+	}
+	return nil
+}
+
+// SwSvanV computes specific volume anomaly for a whole profile at once. S,
+// T, P and out must have equal length.
+func SwSvanV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_svan(S[i], T[i], P[i])
+	}
+	return nil
+}
+
+// SwPtmpV computes potential temperature for a whole profile at once. S,
+// T, P and out must have equal length; PR is the scalar reference pressure
+// shared by all samples.
+func SwPtmpV(S, T, P []float64, PR float64, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_ptmp(S[i], T[i], P[i], PR)
+	}
+	return nil
+}
+
+// SwDpthV computes depth for a whole profile at once. P and out must have
+// equal length; LAT is the scalar latitude shared by all samples.
+func SwDpthV(P []float64, LAT float64, out []float64) error {
+	n := len(P)
+	if len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkPSlice(P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_dpth(P[i], LAT)
+	}
+	return nil
+}
+
+// SwAdtgV computes the adiabatic temperature gradient for a whole profile
+// at once. S, T, P and out must have equal length.
+func SwAdtgV(S, T, P, out []float64) error {
+	n := len(S)
+	if len(T) != n || len(P) != n || len(out) != n {
+		return ErrLengthMismatch
+	}
+	if err := checkSTPSlice(S, T, P); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		out[i] = sw_adtg(S[i], T[i], P[i])
+	}
+	return nil
+}
+
+// sw_dens0_sr is sw_dens0 with SR = sqrt(S) precomputed by the caller, so
+// that SwDensV only takes the square root once per sample instead of once
+// per sub-routine call.
+func sw_dens0_sr(S, T, SR float64) float64 {
+	const b0, b1, b2, b3, b4 = 8.24493e-1, -4.0899e-3, 7.6438e-5, -8.2467e-7, 5.3875e-9
+	const c0, c1, c2 = -5.72466e-3, +1.0227e-4, -1.6546e-6
+	const d0 = 4.8314e-4
+
+	return sw_smow(T) + (b0+(b1+(b2+(b3+b4*T)*T)*T)*T)*S + (c0+(c1+c2*T)*T)*S*SR + d0*S*S
+}
+
+// sw_seck_sr is sw_seck with SR = sqrt(S) precomputed by the caller.
+func sw_seck_sr(S, T, P, SR float64) float64 {
+	P = P / 10.0
+
+	const h3, h2, h1, h0 = -5.77905E-7, +1.16092E-4, +1.43713E-3, +3.239908
+	AW := h0 + (h1+(h2+h3*T)*T)*T
+
+	const k2, k1, k0 = 5.2787E-8, -6.12293E-6, +8.50935E-5
+	BW := k0 + (k1+k2*T)*T
+
+	const e4, e3, e2, e1, e0 = -5.155288E-5, +1.360477E-2, -2.327105, +148.4206, 19652.21
+	KW := e0 + (e1+(e2+(e3+e4*T)*T)*T)*T
+
+	const j0 = 1.91075E-4
+	const i2, i1, i0 = -1.6078E-6, -1.0981E-5, 2.2838E-3
+	A := AW + (i0+(i1+i2*T)*T+j0*SR)*S
+
+	const m2, m1, m0 = 9.1697E-10, +2.0816E-8, -9.9348E-7
+	B := BW + (m0+(m1+m2*T)*T)*S
+
+	const f3, f2, f1, f0 = -6.1670E-5, +1.09987E-2, -0.603459, +54.6746
+	const g2, g1, g0 = -5.3009E-4, +1.6483E-2, +7.944E-2
+	K0 := KW + (f0+(f1+(f2+f3*T)*T)*T+(g0+(g1+g2*T)*T)*SR)*S
+
+	return K0 + (A+B*P)*P
+}
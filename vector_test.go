@@ -0,0 +1,75 @@
+package seawater
+
+import "testing"
+
+func TestSwDensV(t *testing.T) {
+
+	S := []float64{S1, S2}
+	T := []float64{T1, T2}
+	P := []float64{P1, P2}
+	out := make([]float64, 2)
+
+	if err := SwDensV(S, T, P, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if toFixed(out[0], 3) != D1 {
+		t.Errorf("Expected %f, got %f", D1, toFixed(out[0], 3))
+	}
+	if toFixed(out[1], 3) != D2 {
+		t.Errorf("Expected %f, got %f", D2, toFixed(out[1], 3))
+	}
+}
+
+func TestSwDensV_LengthMismatch(t *testing.T) {
+
+	err := SwDensV([]float64{S1}, []float64{T1, T2}, []float64{P1}, []float64{0})
+	if err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch, got %v", err)
+	}
+}
+
+func TestSwDpthV(t *testing.T) {
+
+	P := []float64{P1, P2}
+	out := make([]float64, 2)
+	if err := SwDpthV(P, Lat, out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if toFixed(out[0], 3) != Depth1 {
+		t.Errorf("Expected %f, got %f", Depth1, toFixed(out[0], 3))
+	}
+	if toFixed(out[1], 3) != Depth2 {
+		t.Errorf("Expected %f, got %f", Depth2, toFixed(out[1], 3))
+	}
+}
+
+func benchProfile(n int) (S, T, P, out []float64) {
+	S = make([]float64, n)
+	T = make([]float64, n)
+	P = make([]float64, n)
+	out = make([]float64, n)
+	for i := range S {
+		S[i] = 34.0 + float64(i%10)*0.1
+		T[i] = 10.0 + float64(i%20)*0.2
+		P[i] = float64(i)
+	}
+	return
+}
+
+func BenchmarkSwDensScalar(b *testing.B) {
+	S, T, P, out := benchProfile(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range S {
+			out[j] = sw_dens(S[j], T[j], P[j])
+		}
+	}
+}
+
+func BenchmarkSwDensV(b *testing.B) {
+	S, T, P, out := benchProfile(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SwDensV(S, T, P, out)
+	}
+}